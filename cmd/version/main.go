@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
 	"log"
 	"os"
@@ -10,6 +11,27 @@ import (
 	npm "github.com/khulnasoft-lab/go-npm-version/pkg"
 )
 
+// readVersions reads newline-separated versions from stdin.
+func readVersions() ([]npm.Version, error) {
+	var versions []npm.Version
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		v, err := npm.NewVersion(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse npm version (%s): %w", line, err)
+		}
+		versions = append(versions, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
 func main() {
 	app := &cli.App{
 		Commands: []*cli.Command{
@@ -38,6 +60,12 @@ func main() {
 				Name:    "satisfy",
 				Aliases: []string{"s"},
 				Usage:   "check if the version satisfies the constraint",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "verbose",
+						Usage: "print the reasons why the version does not satisfy the constraint",
+					},
+				},
 				Action: func(c *cli.Context) error {
 					s1 := c.Args().Get(0)
 					v, err := npm.NewVersion(s1)
@@ -51,10 +79,64 @@ func main() {
 						log.Fatalf("failed to parse npm constraint (%s): %s", s2, err)
 					}
 
+					if c.Bool("verbose") {
+						ok, reasons := constraint.Validate(v)
+						fmt.Println(ok)
+						for _, reason := range reasons {
+							fmt.Println(reason)
+						}
+						return nil
+					}
+
 					fmt.Println(constraint.Check(v))
 					return nil
 				},
 			},
+			{
+				Name:  "max-satisfying",
+				Usage: "print the highest version read from stdin that satisfies the constraint",
+				Action: func(c *cli.Context) error {
+					s1 := c.Args().Get(0)
+					constraint, err := npm.NewConstraints(s1)
+					if err != nil {
+						log.Fatalf("failed to parse npm constraint (%s): %s", s1, err)
+					}
+
+					versions, err := readVersions()
+					if err != nil {
+						log.Fatal(err)
+					}
+
+					v, err := npm.MaxSatisfying(versions, constraint)
+					if err != nil {
+						log.Fatal(err)
+					}
+
+					fmt.Println(v)
+					return nil
+				},
+			},
+			{
+				Name:  "filter",
+				Usage: "print the versions read from stdin that satisfy the constraint",
+				Action: func(c *cli.Context) error {
+					s1 := c.Args().Get(0)
+					constraint, err := npm.NewConstraints(s1)
+					if err != nil {
+						log.Fatalf("failed to parse npm constraint (%s): %s", s1, err)
+					}
+
+					versions, err := readVersions()
+					if err != nil {
+						log.Fatal(err)
+					}
+
+					for _, v := range npm.Filter(versions, constraint) {
+						fmt.Println(v)
+					}
+					return nil
+				},
+			},
 		},
 	}
 
@@ -1,8 +1,10 @@
 package npm
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"golang.org/x/xerrors"
@@ -31,6 +33,8 @@ var (
 	}
 	constraintRegexp      *regexp.Regexp
 	validConstraintRegexp *regexp.Regexp
+	versionPartRegexp     *regexp.Regexp
+	hyphenRangeRegexp     *regexp.Regexp
 )
 
 type operatorFunc func(v, c Version, conf conf) bool
@@ -50,6 +54,9 @@ func init() {
 		`^\s*(\s*(%s)\s*(%s)\s*\,?)*\s*$`,
 		strings.Join(ops, "|"),
 		cvRegex))
+
+	versionPartRegexp = regexp.MustCompile(fmt.Sprintf(`^%s$`, cvRegex))
+	hyphenRangeRegexp = regexp.MustCompile(`^(.+?)\s+-\s+(.+)$`)
 }
 
 // Constraints is one or more constraint that a npm version can be
@@ -62,9 +69,21 @@ type Constraints struct {
 type constraint struct {
 	version  Version
 	operator operatorFunc
+	op       string
 	original string
 }
 
+// MustConstraints is like NewConstraints but panics if the constraint
+// string cannot be parsed. It is intended for use in variable
+// initializers where a malformed constraint is a programmer error.
+func MustConstraints(v string, opts ...ConstraintOption) Constraints {
+	cs, err := NewConstraints(v, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return cs
+}
+
 // NewConstraints parses the given string and returns an instance of Constraints
 func NewConstraints(v string, opts ...ConstraintOption) (Constraints, error) {
 	config := new(conf)
@@ -75,6 +94,11 @@ func NewConstraints(v string, opts ...ConstraintOption) (Constraints, error) {
 
 	var css [][]constraint
 	for _, vv := range strings.Split(v, "||") {
+		// Expand hyphen ranges (e.g. "1.2.3 - 2.3.4") and bare X-ranges
+		// (e.g. "1.x", "1.2.x", "*") into explicit operator atoms before
+		// handing the segment to the regex-based parser below.
+		vv = rewriteRange(vv)
+
 		// Validate the segment
 		if !validConstraintRegexp.MatchString(vv) {
 			return Constraints{}, xerrors.Errorf("improper constraint: %s", vv)
@@ -126,6 +150,7 @@ func newConstraint(c string) (constraint, error) {
 	return constraint{
 		version:  v,
 		operator: constraintOperators[m[1]],
+		op:       m[1],
 		original: c,
 	}, nil
 }
@@ -171,6 +196,114 @@ func (cs Constraints) String() string {
 	return strings.Join(csStr, "||")
 }
 
+// Len implements sort.Interface, ordering the OR-groups of cs
+// lexicographically by their string representation.
+func (cs Constraints) Len() int {
+	return len(cs.constraints)
+}
+
+// Less implements sort.Interface.
+func (cs Constraints) Less(i, j int) bool {
+	return orGroupString(cs.constraints[i]) < orGroupString(cs.constraints[j])
+}
+
+// Swap implements sort.Interface.
+func (cs Constraints) Swap(i, j int) {
+	cs.constraints[i], cs.constraints[j] = cs.constraints[j], cs.constraints[i]
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the canonical
+// String() form of cs.
+func (cs Constraints) MarshalText() ([]byte, error) {
+	return []byte(cs.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (cs *Constraints) UnmarshalText(text []byte) error {
+	parsed, err := NewConstraints(string(text))
+	if err != nil {
+		return err
+	}
+	*cs = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (cs Constraints) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cs.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (cs *Constraints) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := NewConstraints(s)
+	if err != nil {
+		return err
+	}
+	*cs = parsed
+	return nil
+}
+
+// Range is a single constraint atom exposed for introspection, e.g. the
+// ">=1.2.3" in ">=1.2.3 <2.0.0".
+type Range struct {
+	Operator string
+	Version  Version
+	Original string
+}
+
+// Prerelease reports whether r's version is a prerelease.
+func (r Range) Prerelease() bool {
+	return r.Version.IsPreRelease()
+}
+
+// Ranges exposes the internal AND/OR structure of cs as a slice of
+// OR-groups, each a slice of AND'd Range atoms.
+func (cs Constraints) Ranges() [][]Range {
+	out := make([][]Range, len(cs.constraints))
+	for i, group := range cs.constraints {
+		ranges := make([]Range, len(group))
+		for j, c := range group {
+			ranges[j] = Range{Operator: c.op, Version: c.version, Original: c.original}
+		}
+		out[i] = ranges
+	}
+	return out
+}
+
+// Prerelease reports whether any constraint atom in cs references a
+// prerelease version.
+func (cs Constraints) Prerelease() bool {
+	for _, group := range cs.constraints {
+		for _, c := range group {
+			if c.version.IsPreRelease() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Bounds returns the normalized interval of each AND-group in cs, with
+// LowerInclusive/UpperInclusive saying whether each bound is included,
+// derived from its operators (and, for "~"/"^", the TildeBump/CaretBump
+// of the anchor version). AND-groups whose bounds admit no version are
+// omitted.
+func (cs Constraints) Bounds() []Interval {
+	return cs.intervals()
+}
+
+func orGroupString(group []constraint) string {
+	var cstr []string
+	for _, c := range group {
+		cstr = append(cstr, c.String())
+	}
+	return strings.Join(cstr, ",")
+}
+
 func andCheck(v Version, constraints []constraint, conf conf) bool {
 	for _, c := range constraints {
 		if !c.check(v, conf) {
@@ -180,6 +313,188 @@ func andCheck(v Version, constraints []constraint, conf conf) bool {
 	return true
 }
 
+// Validate works like Check, but also returns the reasons each failing
+// constraint rejected v.
+func (cs Constraints) Validate(v Version) (bool, []error) {
+	if cs.Check(v) {
+		return true, nil
+	}
+
+	var errs []error
+	for _, orC := range cs.constraints {
+		for _, c := range orC {
+			if !c.check(v, cs.conf) {
+				errs = append(errs, c.validationError(v, cs.conf))
+			}
+		}
+	}
+	return false, errs
+}
+
+// validationError explains why this single constraint rejected v.
+func (c constraint) validationError(v Version, conf conf) error {
+	if !conf.includePreRelease && v.IsPreRelease() && !c.version.IsPreRelease() {
+		return xerrors.Errorf("%s is a prerelease version and constraint %s is not, so it is not allowed", v, c)
+	}
+	if !conf.includePreRelease && v.IsPreRelease() && c.version.IsPreRelease() && !v.Release().Equal(c.version.Release()) {
+		return xerrors.Errorf("%s is a prerelease of a different release than constraint %s, so it is not allowed", v, c)
+	}
+
+	switch c.op {
+	case ">":
+		return xerrors.Errorf("%s is not greater than %s", v, c.version)
+	case ">=", "=>":
+		return xerrors.Errorf("%s is less than %s", v, c.version)
+	case "<":
+		return xerrors.Errorf("%s is not less than %s", v, c.version)
+	case "<=", "=<":
+		return xerrors.Errorf("%s is greater than %s", v, c.version)
+	case "~":
+		return xerrors.Errorf("%s does not match the tilde range %s", v, c)
+	case "^":
+		return xerrors.Errorf("%s does not match the caret range %s", v, c)
+	case "", "=", "==":
+		return xerrors.Errorf("%s is not equal to %s", v, c.version)
+	default:
+		return xerrors.Errorf("%s does not satisfy %s", v, c)
+	}
+}
+
+//-------------------------------------------------------------------
+// Range rewriting (hyphen ranges and bare X-ranges)
+//-------------------------------------------------------------------
+
+// rewriteRange rewrites a single "||"-separated segment, expanding a
+// hyphen range or a bare X-range into equivalent ">="/"<"/"<=" atoms, the
+// same approach Masterminds/semver uses for its own rewriteRange step.
+// Segments that are already operator-prefixed, or don't match either
+// shape, are returned unchanged.
+func rewriteRange(s string) string {
+	if rewritten, ok := rewriteHyphenRange(s); ok {
+		return rewritten
+	}
+	if rewritten, ok := rewriteXRange(s); ok {
+		return rewritten
+	}
+	return s
+}
+
+// rewriteHyphenRange rewrites a whole segment of the form "A - B" into
+// ">=<lower> <=<upper>" (or "<upper>" when the upper bound is partial),
+// expanding partial versions on either side, e.g. "1.2 - 2.3" becomes
+// ">=1.2.0 <2.4.0".
+func rewriteHyphenRange(s string) (string, bool) {
+	m := hyphenRangeRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return s, false
+	}
+
+	lMajor, lMinor, lPatch, lPre, ok := splitVersionParts(m[1])
+	if !ok || lPre != "" {
+		// A prerelease tag on either bound can't be represented by the
+		// plain major.minor.patch bounds below; leave the segment
+		// untouched so it's rejected (or handled) by the caller instead
+		// of silently dropping the prerelease.
+		return s, false
+	}
+	uMajor, uMinor, uPatch, uPre, ok := splitVersionParts(m[2])
+	if !ok || uPre != "" {
+		return s, false
+	}
+
+	lower := fmt.Sprintf(">=%s", lowerBound(lMajor, lMinor, lPatch))
+	upper := upperBound(uMajor, uMinor, uPatch)
+	if upper == "" {
+		return lower, true
+	}
+	return lower + " " + upper, true
+}
+
+// rewriteXRange rewrites a whole segment that is a single, operator-less
+// atom containing a wildcard component ("1.x", "1.2.x", "*", ...) into
+// an equivalent ">="/"<" pair (a lone ">=" atom when the major component
+// is itself a wildcard, as in "*"). Segments that already carry an operator,
+// or that are fully specified versions, are left untouched.
+func rewriteXRange(s string) (string, bool) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return s, false
+	}
+	if strings.ContainsAny(trimmed[:1], "<>=~^") {
+		return s, false
+	}
+
+	major, minor, patch, pre, ok := splitVersionParts(trimmed)
+	if !ok || pre != "" {
+		return s, false
+	}
+	if !isWildcard(major) && !isWildcard(minor) && !isWildcard(patch) {
+		// Fully specified version; equality semantics already apply.
+		return s, false
+	}
+
+	lower := fmt.Sprintf(">=%s", lowerBound(major, minor, patch))
+	if upper := upperBound(major, minor, patch); upper != "" {
+		return lower + " " + upper, true
+	}
+	return lower, true
+}
+
+// splitVersionParts splits a bare version string (which may use x/X/*
+// wildcards and omit trailing components) into its major, minor, patch
+// and prerelease parts.
+func splitVersionParts(s string) (major, minor, patch, pre string, ok bool) {
+	m := versionPartRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return "", "", "", "", false
+	}
+	return m[1], m[2], m[3], m[4], true
+}
+
+func isWildcard(s string) bool {
+	return s == "" || s == "x" || s == "X" || s == "*"
+}
+
+// lowerBound fills any missing/wildcard component with 0 to build the
+// inclusive lower bound of a partial version.
+func lowerBound(major, minor, patch string) string {
+	if isWildcard(major) {
+		major = "0"
+	}
+	if isWildcard(minor) {
+		minor = "0"
+	}
+	if isWildcard(patch) {
+		patch = "0"
+	}
+	return fmt.Sprintf("%s.%s.%s", major, minor, patch)
+}
+
+// upperBound computes the exclusive (or, when fully specified, inclusive)
+// upper bound of a partial version, bumping the least specific given
+// component, e.g. "2" -> "<3.0.0", "2.3" -> "<2.4.0". A fully wildcarded
+// version has no upper bound at all.
+func upperBound(major, minor, patch string) string {
+	switch {
+	case isWildcard(major):
+		return ""
+	case isWildcard(minor):
+		return fmt.Sprintf("<%d.0.0", atoiOrZero(major)+1)
+	case isWildcard(patch):
+		return fmt.Sprintf("<%s.%d.0", major, atoiOrZero(minor)+1)
+	default:
+		return fmt.Sprintf("<=%s.%s.%s", major, minor, patch)
+	}
+}
+
+func atoiOrZero(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
 //-------------------------------------------------------------------
 // Constraint functions
 //-------------------------------------------------------------------
@@ -268,4 +583,4 @@ type WithPreRelease bool
 
 func (o WithPreRelease) apply(c *conf) {
 	c.includePreRelease = bool(o)
-}
\ No newline at end of file
+}
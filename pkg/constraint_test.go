@@ -0,0 +1,100 @@
+package npm
+
+import "testing"
+
+func TestNewConstraints_HyphenRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"within full range", "1.2.3 - 2.3.4", "1.2.3", true},
+		{"upper bound inclusive", "1.2.3 - 2.3.4", "2.3.4", true},
+		{"above full range", "1.2.3 - 2.3.4", "2.3.5", false},
+		{"below full range", "1.2.3 - 2.3.4", "1.2.2", false},
+		{"partial upper bound bumps minor", "1.2 - 2.3", "2.3.9", true},
+		{"partial upper bound is exclusive", "1.2 - 2.3", "2.4.0", false},
+		{"partial lower bound", "1.2 - 2.3", "1.2.0", true},
+		{"below partial lower bound", "1.2 - 2.3", "1.1.9", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs, err := NewConstraints(tt.constraint)
+			if err != nil {
+				t.Fatalf("NewConstraints(%q) returned error: %s", tt.constraint, err)
+			}
+			v, err := NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) returned error: %s", tt.version, err)
+			}
+			if got := cs.Check(v); got != tt.want {
+				t.Errorf("Check(%s) against %q = %v, want %v", tt.version, tt.constraint, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewConstraints_HyphenRangeWithPrereleaseIsRejected(t *testing.T) {
+	if _, err := NewConstraints("1.2.3-alpha - 2.0.0"); err == nil {
+		t.Error("expected an error for a hyphen range with a prerelease bound, got nil")
+	}
+}
+
+func TestNewConstraints_XRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"1.x matches within major", "1.x", "1.9.9", true},
+		{"1.x rejects next major", "1.x", "2.0.0", false},
+		{"1.2.x matches within minor", "1.2.x", "1.2.9", true},
+		{"1.2.x rejects next minor", "1.2.x", "1.3.0", false},
+		{"bare star matches anything", "*", "3.4.5", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs, err := NewConstraints(tt.constraint)
+			if err != nil {
+				t.Fatalf("NewConstraints(%q) returned error: %s", tt.constraint, err)
+			}
+			v, err := NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) returned error: %s", tt.version, err)
+			}
+			if got := cs.Check(v); got != tt.want {
+				t.Errorf("Check(%s) against %q = %v, want %v", tt.version, tt.constraint, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewConstraints_OrInteraction(t *testing.T) {
+	cs, err := NewConstraints("1.2.3 - 2.3.4 || 4.x")
+	if err != nil {
+		t.Fatalf("NewConstraints returned error: %s", err)
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"2.0.0", true},
+		{"4.5.6", true},
+		{"3.0.0", false},
+	}
+
+	for _, tt := range tests {
+		v, err := NewVersion(tt.version)
+		if err != nil {
+			t.Fatalf("NewVersion(%q) returned error: %s", tt.version, err)
+		}
+		if got := cs.Check(v); got != tt.want {
+			t.Errorf("Check(%s) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
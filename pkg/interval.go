@@ -0,0 +1,330 @@
+package npm
+
+import (
+	"strings"
+
+	"github.com/khulnasoft-lab/goversion/pkg/part"
+	"github.com/khulnasoft-lab/goversion/pkg/semver"
+)
+
+// Interval is a half-open (or fully bounded) version range used to
+// reason about Constraints as set algebra: every AND-group of a
+// Constraints value normalizes to exactly one Interval, and the
+// Constraints itself is the union (OR) of those intervals.
+type Interval struct {
+	Lower          Version
+	LowerInclusive bool
+	Upper          Version
+	UpperInclusive bool
+	HasUpper       bool
+
+	// domain and release track prerelease eligibility, the same axis
+	// preCheck gates on in Check: it is orthogonal to the ordering
+	// bounds above, since a plain range like ">=1.0.0 <2.0.0" excludes
+	// every prerelease version even though they fall inside the bounds.
+	domain  prereleaseDomain
+	release Version
+}
+
+// prereleaseDomain records which prerelease versions an AND-group
+// accepts, mirroring preCheck/constraintGreaterThan's rules.
+type prereleaseDomain int
+
+const (
+	// domainNone: no prerelease version ever satisfies the group (the
+	// default, for a group built with no WithPreRelease option and no
+	// constraint atom that is itself a prerelease version).
+	domainNone prereleaseDomain = iota
+	// domainRelease: only prerelease versions of the one release the
+	// group's own prerelease atom(s) pinned to may satisfy the group.
+	domainRelease
+	// domainAll: every version, prerelease or not, is subject only to
+	// the ordering bounds (WithPreRelease(true) was set).
+	domainAll
+)
+
+var zeroVersion = semver.New(part.NewPart("0"), part.NewPart("0"), part.NewPart("0"), part.NewParts(""), "")
+
+func fullInterval() Interval {
+	return Interval{Lower: zeroVersion, LowerInclusive: true, domain: domainAll}
+}
+
+// empty reports whether the interval contains no version at all. This
+// only looks at the ordering bounds: a domainNone/domainRelease
+// interval that is non-empty still matches release versions, it's just
+// barred from matching (some) prereleases.
+func (iv Interval) empty() bool {
+	if !iv.HasUpper {
+		return false
+	}
+	if iv.Lower.GreaterThan(iv.Upper) {
+		return true
+	}
+	if iv.Lower.Equal(iv.Upper) {
+		return !(iv.LowerInclusive && iv.UpperInclusive)
+	}
+	return false
+}
+
+// orderingContains reports whether iv's ordering bounds fully contain
+// other's, ignoring prerelease eligibility.
+func (iv Interval) orderingContains(other Interval) bool {
+	if iv.Lower.GreaterThan(other.Lower) {
+		return false
+	}
+	if iv.Lower.Equal(other.Lower) && !iv.LowerInclusive && other.LowerInclusive {
+		return false
+	}
+
+	if !iv.HasUpper {
+		return true
+	}
+	if !other.HasUpper {
+		return false
+	}
+	if other.Upper.GreaterThan(iv.Upper) {
+		return false
+	}
+	if other.Upper.Equal(iv.Upper) && !iv.UpperInclusive && other.UpperInclusive {
+		return false
+	}
+	return true
+}
+
+// domainContains reports whether every version admitted by other's
+// prerelease domain is also admitted by iv's.
+func (iv Interval) domainContains(other Interval) bool {
+	switch other.domain {
+	case domainNone:
+		return true
+	case domainAll:
+		return iv.domain == domainAll
+	case domainRelease:
+		switch iv.domain {
+		case domainAll:
+			return true
+		case domainRelease:
+			return iv.release.Equal(other.release)
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// contains reports whether iv fully contains other, across both the
+// ordering bounds and prerelease eligibility.
+func (iv Interval) contains(other Interval) bool {
+	return iv.orderingContains(other) && iv.domainContains(other)
+}
+
+// intersectDomain combines two AND'd intervals' prerelease domains,
+// mirroring how preCheck gates the same way for every atom in an
+// AND-group: a version must clear every atom's gate to satisfy the
+// group as a whole.
+func intersectDomain(a, b Interval) (prereleaseDomain, Version) {
+	switch {
+	case a.domain == domainNone || b.domain == domainNone:
+		return domainNone, Version{}
+	case a.domain == domainAll && b.domain == domainAll:
+		return domainAll, Version{}
+	case a.domain == domainAll:
+		return domainRelease, b.release
+	case b.domain == domainAll:
+		return domainRelease, a.release
+	default: // both domainRelease
+		if a.release.Equal(b.release) {
+			return domainRelease, a.release
+		}
+		return domainNone, Version{}
+	}
+}
+
+func intersectInterval(a, b Interval) Interval {
+	lower, lowerInclusive := a.Lower, a.LowerInclusive
+	if b.Lower.GreaterThan(lower) || (b.Lower.Equal(lower) && !b.LowerInclusive) {
+		lower, lowerInclusive = b.Lower, b.LowerInclusive
+	}
+
+	out := Interval{Lower: lower, LowerInclusive: lowerInclusive}
+	switch {
+	case a.HasUpper && b.HasUpper:
+		if a.Upper.LessThan(b.Upper) || (a.Upper.Equal(b.Upper) && !a.UpperInclusive) {
+			out.Upper, out.UpperInclusive, out.HasUpper = a.Upper, a.UpperInclusive, true
+		} else {
+			out.Upper, out.UpperInclusive, out.HasUpper = b.Upper, b.UpperInclusive, true
+		}
+	case a.HasUpper:
+		out.Upper, out.UpperInclusive, out.HasUpper = a.Upper, a.UpperInclusive, true
+	case b.HasUpper:
+		out.Upper, out.UpperInclusive, out.HasUpper = b.Upper, b.UpperInclusive, true
+	}
+	out.domain, out.release = intersectDomain(a, b)
+	return out
+}
+
+// toInterval converts a single constraint atom into the Interval it
+// represents, e.g. ">=1.2.3" becomes [1.2.3, +inf), including the
+// prerelease domain the atom's own operator function would apply.
+func (c constraint) toInterval(conf conf) Interval {
+	var iv Interval
+	switch c.op {
+	case "", "=", "==":
+		iv = Interval{Lower: c.version, LowerInclusive: true, Upper: c.version, UpperInclusive: true, HasUpper: true}
+	case ">":
+		iv = Interval{Lower: c.version, LowerInclusive: false}
+	case ">=", "=>":
+		iv = Interval{Lower: c.version, LowerInclusive: true}
+	case "<":
+		iv = Interval{Lower: zeroVersion, LowerInclusive: true, Upper: c.version, UpperInclusive: false, HasUpper: true}
+	case "<=", "=<":
+		iv = Interval{Lower: zeroVersion, LowerInclusive: true, Upper: c.version, UpperInclusive: true, HasUpper: true}
+	case "~":
+		iv = Interval{Lower: c.version, LowerInclusive: true, Upper: c.version.TildeBump(), UpperInclusive: false, HasUpper: true}
+	case "^":
+		iv = Interval{Lower: c.version, LowerInclusive: true, Upper: c.version.CaretBump(), UpperInclusive: false, HasUpper: true}
+	default:
+		iv = fullInterval()
+	}
+
+	switch {
+	case conf.includePreRelease:
+		iv.domain = domainAll
+	case c.version.IsPreRelease():
+		iv.domain = domainRelease
+		iv.release = c.version.Release()
+	default:
+		iv.domain = domainNone
+	}
+	return iv
+}
+
+// intervals normalizes each AND-group of cs into a single Interval,
+// collapsing the DNF form of Constraints into a slice of disjoint (or
+// possibly overlapping) ranges.
+func (cs Constraints) intervals() []Interval {
+	out := make([]Interval, 0, len(cs.constraints))
+	for _, group := range cs.constraints {
+		iv := fullInterval()
+		for _, c := range group {
+			iv = intersectInterval(iv, c.toInterval(cs.conf))
+		}
+		if !iv.empty() {
+			out = append(out, iv)
+		}
+	}
+	return out
+}
+
+// intervalToConstraintString renders iv back to a constraint string.
+// When domain isn't domainAll, any prerelease tag is stripped from the
+// bounds: this package has no way to express "only prereleases of
+// release R" as a standalone atom once a bound no longer comes
+// directly from a single original "~"/"^"/comparison atom (e.g. after
+// Intersect picks the tighter of two bounds from different operands),
+// so the conservative, always-sound choice is to fall back to
+// domainNone (no prereleases at all) rather than risk admitting a
+// prerelease that shouldn't match. An inclusive prerelease upper bound
+// becomes exclusive on its release, since that release itself lies
+// above the original bound.
+func intervalToConstraintString(iv Interval) string {
+	lower, upper := iv.Lower, iv.Upper
+	if iv.domain != domainAll {
+		lower = lower.Release()
+		if iv.HasUpper {
+			if iv.UpperInclusive && upper.IsPreRelease() {
+				iv.UpperInclusive = false
+			}
+			upper = upper.Release()
+		}
+	}
+
+	lowerOp := ">"
+	if iv.LowerInclusive {
+		lowerOp = ">="
+	}
+	out := lowerOp + lower.String()
+	if !iv.HasUpper {
+		return out
+	}
+	upperOp := "<"
+	if iv.UpperInclusive {
+		upperOp = "<="
+	}
+	return out + " " + upperOp + upper.String()
+}
+
+// mergeIntervals renders a set of OR'd intervals into a Constraints
+// value. The result only uses WithPreRelease(true) when every interval
+// needs domainAll; see intervalToConstraintString for why a mix of
+// domainAll and non-domainAll intervals can't otherwise be represented
+// exactly. In such a mix prerelease eligibility is conservatively
+// dropped: the non-domainAll groups admit no prereleases, and the
+// domainAll groups keep their bounds but are checked without
+// WithPreRelease.
+func mergeIntervals(intervals []Interval) Constraints {
+	if len(intervals) == 0 {
+		return Constraints{}
+	}
+
+	allDomainAll := true
+	raw := make([]string, 0, len(intervals))
+	for _, iv := range intervals {
+		if iv.domain != domainAll {
+			allDomainAll = false
+		}
+		raw = append(raw, intervalToConstraintString(iv))
+	}
+
+	merged, err := NewConstraints(strings.Join(raw, "||"), WithPreRelease(allDomainAll))
+	if err != nil {
+		// raw was built from already-valid constraints, so re-parsing
+		// their canonical form should never fail.
+		panic(err)
+	}
+	return merged
+}
+
+// Intersect returns the constraints that are satisfied by both cs and
+// other, computed by intersecting every pair of AND-group intervals
+// across the two DNF forms.
+func (cs Constraints) Intersect(other Constraints) Constraints {
+	var intervals []Interval
+	for _, a := range cs.intervals() {
+		for _, b := range other.intervals() {
+			iv := intersectInterval(a, b)
+			if !iv.empty() {
+				intervals = append(intervals, iv)
+			}
+		}
+	}
+	return mergeIntervals(intervals)
+}
+
+// Union returns the constraints that are satisfied by either cs or
+// other.
+func (cs Constraints) Union(other Constraints) Constraints {
+	intervals := append(cs.intervals(), other.intervals()...)
+	return mergeIntervals(intervals)
+}
+
+// IsSubsetOf reports whether every interval of cs is fully contained in
+// some single interval of other. An interval of cs that is covered only
+// by several intervals of other taken together is not contained, so the
+// result is false in that case.
+func (cs Constraints) IsSubsetOf(other Constraints) bool {
+	otherIntervals := other.intervals()
+	for _, a := range cs.intervals() {
+		contained := false
+		for _, b := range otherIntervals {
+			if b.contains(a) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			return false
+		}
+	}
+	return true
+}
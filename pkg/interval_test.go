@@ -0,0 +1,143 @@
+package npm
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestConstraints_IsSubsetOf_MixedPrereleaseConfig(t *testing.T) {
+	a, err := NewConstraints(">=1.2.3-alpha <1.3.0", WithPreRelease(true))
+	if err != nil {
+		t.Fatalf("NewConstraints(a) returned error: %s", err)
+	}
+	b, err := NewConstraints(">=1.0.0 <2.0.0")
+	if err != nil {
+		t.Fatalf("NewConstraints(b) returned error: %s", err)
+	}
+
+	if a.IsSubsetOf(b) {
+		t.Error("a.IsSubsetOf(b) = true, want false: b rejects prereleases that a accepts")
+	}
+
+	v, err := NewVersion("1.2.3-alpha")
+	if err != nil {
+		t.Fatalf("NewVersion returned error: %s", err)
+	}
+	if bCheck := b.Check(v); bCheck {
+		t.Fatalf("precondition failed: b.Check(%s) = true, want false", v)
+	}
+	if got := a.Intersect(b).Check(v); got {
+		t.Errorf("a.Intersect(b).Check(%s) = true, want false (b excludes this version)", v)
+	}
+}
+
+func TestConstraints_Intersect(t *testing.T) {
+	a := MustConstraints(">=1.0.0 <3.0.0")
+	b := MustConstraints(">=2.0.0 <4.0.0")
+	got := a.Intersect(b)
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"1.5.0", false},
+		{"2.5.0", true},
+		{"3.5.0", false},
+	}
+	for _, tt := range tests {
+		v := MustNewVersion(tt.version)
+		if g := got.Check(v); g != tt.want {
+			t.Errorf("Intersect(%q, %q).Check(%s) = %v, want %v", a, b, tt.version, g, tt.want)
+		}
+	}
+}
+
+func TestConstraints_Union(t *testing.T) {
+	a := MustConstraints("<1.0.0")
+	b := MustConstraints(">=2.0.0")
+	got := a.Union(b)
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"0.5.0", true},
+		{"1.5.0", false},
+		{"2.5.0", true},
+	}
+	for _, tt := range tests {
+		v := MustNewVersion(tt.version)
+		if g := got.Check(v); g != tt.want {
+			t.Errorf("Union(%q, %q).Check(%s) = %v, want %v", a, b, tt.version, g, tt.want)
+		}
+	}
+}
+
+func TestConstraints_IsSubsetOf(t *testing.T) {
+	narrow := MustConstraints(">=1.5.0 <2.0.0")
+	wide := MustConstraints(">=1.0.0 <3.0.0")
+
+	if !narrow.IsSubsetOf(wide) {
+		t.Error("narrow.IsSubsetOf(wide) = false, want true")
+	}
+	if wide.IsSubsetOf(narrow) {
+		t.Error("wide.IsSubsetOf(narrow) = true, want false")
+	}
+}
+
+func TestMustConstraints(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustConstraints did not panic on an invalid constraint")
+		}
+	}()
+	MustConstraints("not a constraint")
+}
+
+func TestConstraints_SortInterface(t *testing.T) {
+	css := []Constraints{
+		MustConstraints(">=2.0.0"),
+		MustConstraints(">=1.0.0"),
+	}
+
+	sort.Sort(sortableConstraints(css))
+
+	if css[0].String() != ">=1.0.0" || css[1].String() != ">=2.0.0" {
+		t.Errorf("sort.Sort did not order constraints lexicographically, got %q, %q", css[0], css[1])
+	}
+}
+
+// sortableConstraints adapts a []Constraints slice to sort.Interface,
+// ordering whole Constraints values by their String() form;
+// Constraints.Len/Less/Swap themselves operate
+// on the OR-groups within one Constraints value, so this wrapper is how
+// a caller would sort a collection of whole Constraints values.
+type sortableConstraints []Constraints
+
+func (s sortableConstraints) Len() int      { return len(s) }
+func (s sortableConstraints) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortableConstraints) Less(i, j int) bool {
+	return s[i].String() < s[j].String()
+}
+
+func TestConstraints_LenLessSwap(t *testing.T) {
+	cs := MustConstraints(">=2.0.0 || >=1.0.0")
+	if cs.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", cs.Len())
+	}
+	if !cs.Less(1, 0) {
+		t.Error("Less(1, 0) = false, want true: the second OR-group sorts before the first")
+	}
+	cs.Swap(0, 1)
+	if cs.String() != ">=1.0.0||>=2.0.0" {
+		t.Errorf("after Swap, String() = %q, want %q", cs.String(), ">=1.0.0||>=2.0.0")
+	}
+}
+
+func MustNewVersion(s string) Version {
+	v, err := NewVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,87 @@
+package npm
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConstraints_MarshalUnmarshalJSON(t *testing.T) {
+	cs := MustConstraints(">=1.0.0 <2.0.0")
+
+	data, err := json.Marshal(cs)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %s", err)
+	}
+	want, _ := json.Marshal(cs.String())
+	if string(data) != string(want) {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var got Constraints
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %s", err)
+	}
+	if got.String() != cs.String() {
+		t.Errorf("Unmarshal = %s, want %s", got, cs)
+	}
+}
+
+func TestConstraints_UnmarshalJSON_Invalid(t *testing.T) {
+	var got Constraints
+	if err := json.Unmarshal([]byte(`"not a constraint"`), &got); err == nil {
+		t.Error("Unmarshal of an invalid constraint returned nil error")
+	}
+}
+
+func TestConstraints_Ranges(t *testing.T) {
+	cs := MustConstraints(">=1.0.0 <2.0.0 || ~3.0.0")
+
+	groups := cs.Ranges()
+	if len(groups) != 2 {
+		t.Fatalf("Ranges() returned %d OR-groups, want 2", len(groups))
+	}
+	if len(groups[0]) != 2 || groups[0][0].Operator != ">=" || groups[0][1].Operator != "<" {
+		t.Errorf("Ranges()[0] = %+v, want two atoms [>= <]", groups[0])
+	}
+	if len(groups[1]) != 1 || groups[1][0].Operator != "~" {
+		t.Errorf("Ranges()[1] = %+v, want one atom [~]", groups[1])
+	}
+}
+
+func TestRange_Prerelease(t *testing.T) {
+	cs := MustConstraints(">=1.0.0-alpha")
+	r := cs.Ranges()[0][0]
+	if !r.Prerelease() {
+		t.Error("Prerelease() = false, want true for a prerelease atom")
+	}
+
+	cs2 := MustConstraints(">=1.0.0")
+	r2 := cs2.Ranges()[0][0]
+	if r2.Prerelease() {
+		t.Error("Prerelease() = true, want false for a non-prerelease atom")
+	}
+}
+
+func TestConstraints_Prerelease(t *testing.T) {
+	if !MustConstraints(">=1.0.0-alpha <2.0.0").Prerelease() {
+		t.Error("Prerelease() = false, want true when any atom is a prerelease version")
+	}
+	if MustConstraints(">=1.0.0 <2.0.0").Prerelease() {
+		t.Error("Prerelease() = true, want false when no atom is a prerelease version")
+	}
+}
+
+func TestConstraints_Bounds(t *testing.T) {
+	cs := MustConstraints(">=1.0.0 <2.0.0")
+	bounds := cs.Bounds()
+	if len(bounds) != 1 {
+		t.Fatalf("Bounds() returned %d intervals, want 1", len(bounds))
+	}
+	if bounds[0].Lower.String() != "1.0.0" || !bounds[0].LowerInclusive {
+		t.Errorf("Bounds()[0].Lower = %s (inclusive=%v), want 1.0.0 (inclusive=true)", bounds[0].Lower, bounds[0].LowerInclusive)
+	}
+	if !bounds[0].HasUpper || bounds[0].Upper.String() != "2.0.0" || bounds[0].UpperInclusive {
+		t.Errorf("Bounds()[0].Upper = %s (hasUpper=%v, inclusive=%v), want 2.0.0 (hasUpper=true, inclusive=false)",
+			bounds[0].Upper, bounds[0].HasUpper, bounds[0].UpperInclusive)
+	}
+}
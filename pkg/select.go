@@ -0,0 +1,89 @@
+package npm
+
+import (
+	"sort"
+
+	"golang.org/x/xerrors"
+)
+
+// Sort sorts the given versions in ascending order, in place.
+func Sort(versions []Version) {
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].LessThan(versions[j])
+	})
+}
+
+// Filter returns the subset of versions that satisfy cs.
+func Filter(versions []Version, cs Constraints) []Version {
+	var out []Version
+	for _, v := range versions {
+		if cs.Check(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Newest returns the highest version among versions that satisfies cs.
+// It returns false if no version satisfies the constraints.
+func Newest(versions []Version, cs Constraints) (Version, bool) {
+	var best Version
+	found := false
+	for _, v := range versions {
+		if !cs.Check(v) {
+			continue
+		}
+		if !found || v.GreaterThan(best) {
+			best = v
+			found = true
+		}
+	}
+	return best, found
+}
+
+// Oldest returns the lowest version among versions that satisfies cs.
+// It returns false if no version satisfies the constraints.
+func Oldest(versions []Version, cs Constraints) (Version, bool) {
+	var best Version
+	found := false
+	for _, v := range versions {
+		if !cs.Check(v) {
+			continue
+		}
+		if !found || v.LessThan(best) {
+			best = v
+			found = true
+		}
+	}
+	return best, found
+}
+
+// MaxSatisfying returns the highest version in versions that satisfies
+// cs, mirroring node-semver's maxSatisfying. opts, if given, are applied
+// on top of cs's own options (e.g. to allow prereleases for this lookup
+// without rebuilding the constraints).
+func MaxSatisfying(versions []Version, cs Constraints, opts ...ConstraintOption) (Version, error) {
+	for _, o := range opts {
+		o.apply(&cs.conf)
+	}
+
+	v, ok := Newest(versions, cs)
+	if !ok {
+		return Version{}, xerrors.Errorf("no version satisfies the constraints: %s", cs)
+	}
+	return v, nil
+}
+
+// MinSatisfying returns the lowest version in versions that satisfies
+// cs, mirroring node-semver's minSatisfying.
+func MinSatisfying(versions []Version, cs Constraints, opts ...ConstraintOption) (Version, error) {
+	for _, o := range opts {
+		o.apply(&cs.conf)
+	}
+
+	v, ok := Oldest(versions, cs)
+	if !ok {
+		return Version{}, xerrors.Errorf("no version satisfies the constraints: %s", cs)
+	}
+	return v, nil
+}
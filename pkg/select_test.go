@@ -0,0 +1,85 @@
+package npm
+
+import "testing"
+
+func versions(ss ...string) []Version {
+	out := make([]Version, len(ss))
+	for i, s := range ss {
+		out[i] = MustNewVersion(s)
+	}
+	return out
+}
+
+func TestSort(t *testing.T) {
+	vs := versions("2.0.0", "1.0.0", "1.5.0")
+	Sort(vs)
+
+	want := []string{"1.0.0", "1.5.0", "2.0.0"}
+	for i, w := range want {
+		if vs[i].String() != w {
+			t.Errorf("Sort()[%d] = %s, want %s", i, vs[i], w)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	vs := versions("1.0.0", "1.5.0", "2.0.0")
+	cs := MustConstraints("<2.0.0")
+
+	got := Filter(vs, cs)
+	if len(got) != 2 || got[0].String() != "1.0.0" || got[1].String() != "1.5.0" {
+		t.Errorf("Filter() = %v, want [1.0.0 1.5.0]", got)
+	}
+}
+
+func TestNewestOldest(t *testing.T) {
+	vs := versions("1.0.0", "1.5.0", "2.0.0")
+	cs := MustConstraints("<2.0.0")
+
+	newest, ok := Newest(vs, cs)
+	if !ok || newest.String() != "1.5.0" {
+		t.Errorf("Newest() = %s, %v, want 1.5.0, true", newest, ok)
+	}
+
+	oldest, ok := Oldest(vs, cs)
+	if !ok || oldest.String() != "1.0.0" {
+		t.Errorf("Oldest() = %s, %v, want 1.0.0, true", oldest, ok)
+	}
+
+	if _, ok := Newest(vs, MustConstraints(">=3.0.0")); ok {
+		t.Error("Newest() with no satisfying version returned ok = true")
+	}
+}
+
+func TestMaxMinSatisfying(t *testing.T) {
+	vs := versions("1.0.0", "1.5.0", "2.0.0")
+	cs := MustConstraints("<2.0.0")
+
+	max, err := MaxSatisfying(vs, cs)
+	if err != nil || max.String() != "1.5.0" {
+		t.Errorf("MaxSatisfying() = %s, %v, want 1.5.0, nil", max, err)
+	}
+
+	min, err := MinSatisfying(vs, cs)
+	if err != nil || min.String() != "1.0.0" {
+		t.Errorf("MinSatisfying() = %s, %v, want 1.0.0, nil", min, err)
+	}
+
+	if _, err := MaxSatisfying(vs, MustConstraints(">=3.0.0")); err == nil {
+		t.Error("MaxSatisfying() with no satisfying version returned nil error")
+	}
+}
+
+func TestMaxSatisfying_OptsOverridePrerelease(t *testing.T) {
+	vs := versions("1.0.0-alpha")
+	cs := MustConstraints(">=0.9.0")
+
+	if _, err := MaxSatisfying(vs, cs); err == nil {
+		t.Fatal("MaxSatisfying() without WithPreRelease(true) unexpectedly satisfied a prerelease-only set")
+	}
+
+	v, err := MaxSatisfying(vs, cs, WithPreRelease(true))
+	if err != nil || v.String() != "1.0.0-alpha" {
+		t.Errorf("MaxSatisfying() with WithPreRelease(true) = %s, %v, want 1.0.0-alpha, nil", v, err)
+	}
+}
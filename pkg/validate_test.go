@@ -0,0 +1,37 @@
+package npm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConstraints_Validate(t *testing.T) {
+	cs := MustConstraints(">=1.0.0 <2.0.0")
+
+	if ok, errs := cs.Validate(MustNewVersion("1.5.0")); !ok || errs != nil {
+		t.Errorf("Validate(1.5.0) = %v, %v, want true, nil", ok, errs)
+	}
+
+	ok, errs := cs.Validate(MustNewVersion("2.5.0"))
+	if ok {
+		t.Fatal("Validate(2.5.0) = true, want false")
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "not less than") {
+		t.Errorf("Validate(2.5.0) errors = %v, want one error mentioning \"not less than\"", errs)
+	}
+}
+
+func TestConstraints_Validate_PrereleaseReasons(t *testing.T) {
+	cs := MustConstraints(">=1.0.0")
+
+	_, errs := cs.Validate(MustNewVersion("1.5.0-alpha"))
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "is a prerelease version and constraint") {
+		t.Errorf("Validate(1.5.0-alpha) errors = %v, want a prerelease-mismatch reason", errs)
+	}
+
+	cs2 := MustConstraints(">=2.0.0-alpha")
+	_, errs2 := cs2.Validate(MustNewVersion("1.0.0-alpha"))
+	if len(errs2) != 1 || !strings.Contains(errs2[0].Error(), "prerelease of a different release") {
+		t.Errorf("Validate(1.0.0-alpha) errors = %v, want a different-release reason", errs2)
+	}
+}
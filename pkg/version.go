@@ -1,6 +1,10 @@
 package npm
 
-import "github.com/khulnasoft-lab/goversion/pkg/semver"
+import (
+	"encoding/json"
+
+	"github.com/khulnasoft-lab/goversion/pkg/semver"
+)
 
 // Version represents a semantic version.
 type Version = semver.Version
@@ -8,4 +12,46 @@ type Version = semver.Version
 // NewVersion parses a given version and returns an instance of Version
 func NewVersion(s string) (Version, error) {
 	return semver.Parse(s)
-}
\ No newline at end of file
+}
+
+// JSONVersion wraps Version so it can implement the TextMarshaler,
+// TextUnmarshaler, json.Marshaler and json.Unmarshaler interfaces.
+// Version itself cannot carry these methods because it is a type alias
+// for semver.Version, a type this package doesn't own.
+type JSONVersion struct {
+	Version
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v JSONVersion) MarshalText() ([]byte, error) {
+	return []byte(v.Version.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *JSONVersion) UnmarshalText(text []byte) error {
+	parsed, err := NewVersion(string(text))
+	if err != nil {
+		return err
+	}
+	v.Version = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v JSONVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Version.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *JSONVersion) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := NewVersion(s)
+	if err != nil {
+		return err
+	}
+	v.Version = parsed
+	return nil
+}
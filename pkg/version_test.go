@@ -0,0 +1,50 @@
+package npm
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONVersion_MarshalUnmarshalJSON(t *testing.T) {
+	jv := JSONVersion{Version: MustNewVersion("1.2.3-alpha")}
+
+	data, err := json.Marshal(jv)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %s", err)
+	}
+	if string(data) != `"1.2.3-alpha"` {
+		t.Errorf("Marshal = %s, want %q", data, `"1.2.3-alpha"`)
+	}
+
+	var got JSONVersion
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %s", err)
+	}
+	if !got.Version.Equal(jv.Version) {
+		t.Errorf("Unmarshal = %s, want %s", got.Version, jv.Version)
+	}
+}
+
+func TestJSONVersion_UnmarshalJSON_Invalid(t *testing.T) {
+	var got JSONVersion
+	if err := json.Unmarshal([]byte(`"not a version"`), &got); err == nil {
+		t.Error("Unmarshal of an invalid version returned nil error")
+	}
+}
+
+func TestJSONVersion_MarshalUnmarshalText(t *testing.T) {
+	jv := JSONVersion{Version: MustNewVersion("2.0.0")}
+
+	text, err := jv.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText returned error: %s", err)
+	}
+
+	var got JSONVersion
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText returned error: %s", err)
+	}
+	if !got.Version.Equal(jv.Version) {
+		t.Errorf("UnmarshalText = %s, want %s", got.Version, jv.Version)
+	}
+}